@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Config holds the CLI flags that shape how the picker behaves: which
+// entries it lists and where it starts.
+type Config struct {
+	DirOnly    bool
+	FileOnly   bool
+	ShowHidden bool
+	StartDir   string
+	Height     int
+}
+
+// parseFlags parses args (typically os.Args[1:]) into a Config. Long and
+// short forms are registered as aliases of the same flag.
+func parseFlags(args []string) (Config, error) {
+	var cfg Config
+
+	fs := flag.NewFlagSet("path-cp", flag.ContinueOnError)
+	fs.BoolVar(&cfg.DirOnly, "d", false, "show only directories (directory-picker mode)")
+	fs.BoolVar(&cfg.DirOnly, "dir-only", false, "show only directories (directory-picker mode)")
+	fs.BoolVar(&cfg.FileOnly, "f", false, "hide directories, showing only files")
+	fs.BoolVar(&cfg.FileOnly, "file-only", false, "hide directories, showing only files")
+	fs.BoolVar(&cfg.ShowHidden, "show-hidden", false, "show dot-prefixed entries")
+	fs.StringVar(&cfg.StartDir, "start-dir", "", "directory to open instead of the working directory")
+	fs.IntVar(&cfg.Height, "height", 0, "fixed list height in rows (0 fills the terminal)")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.DirOnly && cfg.FileOnly {
+		return Config{}, fmt.Errorf("--dir-only and --file-only are mutually exclusive")
+	}
+
+	return cfg, nil
+}
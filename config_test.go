@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestParseFlags(t *testing.T) {
+	cfg, err := parseFlags([]string{"--dir-only", "--show-hidden", "--start-dir", "/tmp", "--height", "20"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+
+	want := Config{DirOnly: true, ShowHidden: true, StartDir: "/tmp", Height: 20}
+	if cfg != want {
+		t.Fatalf("parseFlags() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestParseFlagsShortForms(t *testing.T) {
+	cfg, err := parseFlags([]string{"-d"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !cfg.DirOnly {
+		t.Fatal("expected -d to set DirOnly")
+	}
+}
+
+func TestParseFlagsMutuallyExclusive(t *testing.T) {
+	if _, err := parseFlags([]string{"--dir-only", "--file-only"}); err == nil {
+		t.Fatal("expected error when both --dir-only and --file-only are set")
+	}
+}
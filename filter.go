@@ -0,0 +1,45 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/sahilm/fuzzy"
+)
+
+// fuzzyFilter ranks targets by fuzzy.Find score, matching list.FilterFunc.
+func fuzzyFilter(term string, targets []string) []list.Rank {
+	matches := fuzzy.Find(term, targets)
+	sort.Stable(matches)
+
+	ranks := make([]list.Rank, len(matches))
+	for i, match := range matches {
+		ranks[i] = list.Rank{
+			Index:          match.Index,
+			MatchedIndexes: match.MatchedIndexes,
+		}
+	}
+	return ranks
+}
+
+// substringFilter ranks targets by a case-insensitive substring match,
+// preserving input order and highlighting the whole matched run.
+func substringFilter(term string, targets []string) []list.Rank {
+	term = strings.ToLower(term)
+
+	var ranks []list.Rank
+	for i, target := range targets {
+		pos := strings.Index(strings.ToLower(target), term)
+		if pos < 0 {
+			continue
+		}
+
+		matched := make([]int, len(term))
+		for j := range matched {
+			matched[j] = pos + j
+		}
+		ranks = append(ranks, list.Rank{Index: i, MatchedIndexes: matched})
+	}
+	return ranks
+}
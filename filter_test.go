@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestSubstringFilter(t *testing.T) {
+	targets := []string{"main.go", "README.md", "go.mod"}
+
+	ranks := substringFilter("go", targets)
+	if len(ranks) != 2 {
+		t.Fatalf("len(ranks) = %d, want 2", len(ranks))
+	}
+	if ranks[0].Index != 0 || ranks[1].Index != 2 {
+		t.Fatalf("ranks = %+v, want indexes [0, 2]", ranks)
+	}
+}
+
+func TestSubstringFilterCaseInsensitive(t *testing.T) {
+	ranks := substringFilter("README", []string{"readme.md"})
+	if len(ranks) != 1 {
+		t.Fatalf("len(ranks) = %d, want 1", len(ranks))
+	}
+}
+
+func TestFuzzyFilterRanksByScore(t *testing.T) {
+	targets := []string{"zzz_mg.txt", "main.go"}
+
+	ranks := fuzzyFilter("mg", targets)
+	if len(ranks) != 2 {
+		t.Fatalf("len(ranks) = %d, want 2", len(ranks))
+	}
+	if ranks[0].Index != 1 {
+		t.Fatalf("expected the tighter match (main.go) to rank first, got index %d", ranks[0].Index)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathFormatter turns the selected path into the string that gets copied
+// to the clipboard.
+type PathFormatter interface {
+	Format(selected, initial, home string) (string, error)
+}
+
+// formatterFunc adapts a plain function to PathFormatter.
+type formatterFunc func(selected, initial, home string) (string, error)
+
+func (f formatterFunc) Format(selected, initial, home string) (string, error) {
+	return f(selected, initial, home)
+}
+
+// formatEntry binds a single-rune keybinding to a formatter and the
+// help text shown for it.
+type formatEntry struct {
+	key         rune
+	description string
+	formatter   PathFormatter
+}
+
+// formatRegistry is the set of path formats the user can copy with,
+// keyed by the rune pressed to select them. Built-in formats are
+// registered first; user formats loaded from formats.yaml are appended
+// and may not override a built-in key.
+type formatRegistry struct {
+	entries []formatEntry
+}
+
+// newFormatRegistry returns a registry populated with path-cp's built-in
+// formats: f (file), r (relative), a (absolute), d (directory path), and
+// p (`$HOME`-relative).
+func newFormatRegistry() *formatRegistry {
+	r := &formatRegistry{}
+	r.register('f', "file", formatterFunc(formatFileName))
+	r.register('r', "relative", formatterFunc(formatRelative))
+	r.register('a', "absolute", formatterFunc(formatAbsolute))
+	r.register('d', "directory path", formatterFunc(formatDirectory))
+	r.register('p', "`$HOME` format", formatterFunc(formatHomeRelative))
+	return r
+}
+
+func (r *formatRegistry) register(key rune, description string, formatter PathFormatter) {
+	r.entries = append(r.entries, formatEntry{key: key, description: description, formatter: formatter})
+}
+
+// reservedKeys are the single-rune keybindings handleKeyPress matches
+// before it ever falls through to the format registry's lookup. A format
+// bound to one of these would show up in helpText but could never fire,
+// so loadUserFormats refuses to register them.
+var reservedKeys = map[rune]bool{
+	'q': true, 'g': true, ' ': true,
+	'n': true, 'N': true, 'R': true, 'm': true, 'c': true, 'x': true,
+	'h': true, 'l': true,
+}
+
+// has reports whether key is already registered.
+func (r *formatRegistry) has(key rune) bool {
+	_, ok := r.lookup(key)
+	return ok
+}
+
+// lookup returns the formatter registered for key, if any.
+func (r *formatRegistry) lookup(key rune) (PathFormatter, bool) {
+	for _, entry := range r.entries {
+		if entry.key == key {
+			return entry.formatter, true
+		}
+	}
+	return nil, false
+}
+
+// helpText renders the "Copy: f (file) • r (relative) • ..." line shown
+// in View(), generated from the registry so user formats show up
+// automatically.
+func (r *formatRegistry) helpText() string {
+	parts := make([]string, len(r.entries))
+	for i, entry := range r.entries {
+		parts[i] = fmt.Sprintf("%c (%s)", entry.key, entry.description)
+	}
+	return "Copy: " + strings.Join(parts, " • ")
+}
+
+func formatFileName(selected, _, _ string) (string, error) {
+	return filepath.Base(selected), nil
+}
+
+func formatRelative(selected, initial, _ string) (string, error) {
+	relPath, err := filepath.Rel(initial, selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relative path: %w", err)
+	}
+	return relPath, nil
+}
+
+func formatAbsolute(selected, _, _ string) (string, error) {
+	absPath, err := filepath.Abs(selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	return absPath, nil
+}
+
+func formatDirectory(selected, _, _ string) (string, error) {
+	absPath, err := filepath.Abs(selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if info, err := os.Stat(absPath); err == nil && !info.IsDir() {
+		return filepath.Dir(absPath), nil
+	}
+	return absPath, nil
+}
+
+func formatHomeRelative(selected, _, home string) (string, error) {
+	absPath, err := filepath.Abs(selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	dirPath := absPath
+	if info, err := os.Stat(absPath); err == nil && !info.IsDir() {
+		dirPath = filepath.Dir(absPath)
+	}
+
+	if home != "" {
+		if relPath, err := filepath.Rel(home, dirPath); err == nil && !strings.HasPrefix(relPath, "..") {
+			return filepath.Join("$HOME", relPath), nil
+		}
+	}
+	return dirPath, nil
+}
+
+// templateData is the set of fields available to a user-defined format
+// template in formats.yaml.
+type templateData struct {
+	Abs     string
+	Rel     string
+	Base    string
+	Dir     string
+	HomeRel string
+	URI     string
+}
+
+// templateFormatter runs a user-supplied text/template against
+// templateData derived from the selected path.
+type templateFormatter struct {
+	key      string
+	template *template.Template
+}
+
+func (t templateFormatter) Format(selected, initial, home string) (string, error) {
+	absPath, err := filepath.Abs(selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	relPath, err := filepath.Rel(initial, absPath)
+	if err != nil {
+		relPath = absPath
+	}
+
+	homeRel := absPath
+	if home != "" {
+		if r, err := filepath.Rel(home, absPath); err == nil && !strings.HasPrefix(r, "..") {
+			homeRel = r
+		}
+	}
+
+	data := templateData{
+		Abs:     absPath,
+		Rel:     relPath,
+		Base:    filepath.Base(absPath),
+		Dir:     filepath.Dir(absPath),
+		HomeRel: homeRel,
+		URI:     "file://" + absPath,
+	}
+
+	var buf strings.Builder
+	if err := t.template.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("format %q: %w", t.key, err)
+	}
+	return buf.String(), nil
+}
+
+// userFormat is one entry of ~/.config/path-cp/formats.yaml.
+type userFormat struct {
+	Key      string `yaml:"key"`
+	Template string `yaml:"template"`
+}
+
+// loadUserFormats reads and registers the formats defined in
+// ~/.config/path-cp/formats.yaml into r. A missing file is not an error;
+// a malformed one is.
+func loadUserFormats(r *formatRegistry, path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var userFormats []userFormat
+	if err := yaml.Unmarshal(data, &userFormats); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for _, uf := range userFormats {
+		keyRunes := []rune(uf.Key)
+		if len(keyRunes) != 1 {
+			return fmt.Errorf("%s: format key %q must be a single character", path, uf.Key)
+		}
+		key := keyRunes[0]
+		if reservedKeys[key] {
+			return fmt.Errorf("%s: format key %q is reserved for navigation/file-ops", path, uf.Key)
+		}
+		if r.has(key) {
+			return fmt.Errorf("%s: format key %q is already in use", path, uf.Key)
+		}
+
+		tmpl, err := template.New(uf.Key).Parse(uf.Template)
+		if err != nil {
+			return fmt.Errorf("%s: format %q: %w", path, uf.Key, err)
+		}
+
+		r.register(key, uf.Template, templateFormatter{key: uf.Key, template: tmpl})
+	}
+	return nil
+}
+
+// userFormatsPath returns the path formats.yaml is loaded from, or "" if
+// the user config directory can't be determined.
+func userFormatsPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "path-cp", "formats.yaml")
+}
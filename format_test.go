@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatRegistryBuiltins(t *testing.T) {
+	r := newFormatRegistry()
+	for _, key := range []rune{'f', 'r', 'a', 'd', 'p'} {
+		if !r.has(key) {
+			t.Fatalf("registry missing built-in key %q", key)
+		}
+	}
+	if r.has('z') {
+		t.Fatal("registry reports unregistered key as present")
+	}
+}
+
+func TestFormatRegistryHelpText(t *testing.T) {
+	r := newFormatRegistry()
+	got := r.helpText()
+	want := "Copy: f (file) • r (relative) • a (absolute) • d (directory path) • p (`$HOME` format)"
+	if got != want {
+		t.Fatalf("helpText() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatFileName(t *testing.T) {
+	got, err := formatFileName(filepath.Join("a", "b", "c.txt"), "", "")
+	if err != nil {
+		t.Fatalf("formatFileName() error = %v", err)
+	}
+	if got != "c.txt" {
+		t.Fatalf("formatFileName() = %q, want %q", got, "c.txt")
+	}
+}
+
+func TestFormatHomeRelative(t *testing.T) {
+	home := t.TempDir()
+	dir := filepath.Join(home, "proj")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := formatHomeRelative(dir, "", home)
+	if err != nil {
+		t.Fatalf("formatHomeRelative() error = %v", err)
+	}
+	want := filepath.Join("$HOME", "proj")
+	if got != want {
+		t.Fatalf("formatHomeRelative() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadUserFormatsMissingFileIsNotError(t *testing.T) {
+	r := newFormatRegistry()
+	if err := loadUserFormats(r, filepath.Join(t.TempDir(), "formats.yaml")); err != nil {
+		t.Fatalf("loadUserFormats() error = %v, want nil for missing file", err)
+	}
+}
+
+func TestLoadUserFormatsRegistersTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "formats.yaml")
+	writeFile(t, path, "- key: \"u\"\n  template: \"{{.Base}}\"\n")
+
+	r := newFormatRegistry()
+	if err := loadUserFormats(r, path); err != nil {
+		t.Fatalf("loadUserFormats() error = %v", err)
+	}
+
+	formatter, ok := r.lookup('u')
+	if !ok {
+		t.Fatal("loadUserFormats() did not register key 'u'")
+	}
+	got, err := formatter.Format(filepath.Join("a", "b.txt"), "", "")
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if got != "b.txt" {
+		t.Fatalf("Format() = %q, want %q", got, "b.txt")
+	}
+}
+
+func TestLoadUserFormatsRejectsBuiltinOverride(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "formats.yaml")
+	writeFile(t, path, "- key: \"f\"\n  template: \"{{.Base}}\"\n")
+
+	r := newFormatRegistry()
+	if err := loadUserFormats(r, path); err == nil {
+		t.Fatal("loadUserFormats() error = nil, want error overriding built-in key")
+	}
+}
+
+func TestLoadUserFormatsRejectsReservedKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "formats.yaml")
+	writeFile(t, path, "- key: \"n\"\n  template: \"{{.Base}}\"\n")
+
+	r := newFormatRegistry()
+	if err := loadUserFormats(r, path); err == nil {
+		t.Fatal("loadUserFormats() error = nil, want error registering reserved key")
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,150 @@
+// Package fileops implements the filesystem primitives backing path-cp's
+// file-operations subsystem (create, rename, move, copy, delete). The
+// functions here are pure with respect to the TUI: they take and return
+// plain paths and errors so the model layer can stay thin and testable.
+package fileops
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CreateFile creates an empty file at path, failing if it already exists.
+func CreateFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// CreateDir creates path and any missing parents.
+func CreateDir(path string) error {
+	return os.MkdirAll(path, 0o755)
+}
+
+// Rename moves oldPath to newPath, the same semantics as mv on one path.
+func Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// Remove deletes path, recursively if it is a directory.
+func Remove(path string) error {
+	return os.RemoveAll(path)
+}
+
+// RemoveBatch deletes every path in paths, stopping at the first error.
+func RemoveBatch(paths []string) error {
+	for _, path := range paths {
+		if err := Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copy copies src to dst, recursing into directories. It refuses to copy
+// src onto itself or into its own subtree, since copyFile's O_EXCL write
+// would otherwise be the only thing standing between that and data loss.
+func Copy(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if absSrc == absDst {
+		return fmt.Errorf("cannot copy %s onto itself", src)
+	}
+	if info.IsDir() && isWithin(absDst, absSrc) {
+		return fmt.Errorf("cannot copy %s into its own subdirectory %s", src, dst)
+	}
+
+	if info.IsDir() {
+		return copyDir(src, dst)
+	}
+	return copyFile(src, dst, info.Mode())
+}
+
+// isWithin reports whether path is dir itself or nested inside it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || !strings.HasPrefix(rel, "..")
+}
+
+// CopyBatch copies every entry in srcs into destDir, keeping each entry's
+// base name.
+func CopyBatch(srcs []string, destDir string) error {
+	for _, src := range srcs {
+		dst := filepath.Join(destDir, filepath.Base(src))
+		if err := Copy(src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveBatch moves every entry in srcs into destDir, keeping each entry's
+// base name.
+func MoveBatch(srcs []string, destDir string) error {
+	for _, src := range srcs {
+		dst := filepath.Join(destDir, filepath.Base(src))
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyDir(src, dst string) error {
+	return fs.WalkDir(os.DirFS(src), ".", func(relPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		srcPath := filepath.Join(src, relPath)
+		dstPath := filepath.Join(dst, relPath)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode())
+		}
+		return copyFile(srcPath, dstPath, info.Mode())
+	})
+}
+
+// copyFile copies src to dst, refusing (like CreateFile) to overwrite an
+// existing dst rather than silently clobbering it.
+func copyFile(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
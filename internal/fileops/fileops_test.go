@@ -0,0 +1,221 @@
+package fileops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "new.txt")
+
+	if err := CreateFile(path); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected file to exist, got error: %v", err)
+	}
+
+	if err := CreateFile(path); err == nil {
+		t.Fatal("expected error when file already exists, got nil")
+	}
+}
+
+func TestCreateDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a", "b", "c")
+
+	if err := CreateDir(path); err != nil {
+		t.Fatalf("CreateDir() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected directory to exist, got error: %v", err)
+	}
+	if !info.IsDir() {
+		t.Fatal("expected path to be a directory")
+	}
+}
+
+func TestRename(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.txt")
+	newPath := filepath.Join(dir, "new.txt")
+	writeFile(t, oldPath, "hello")
+
+	if err := Rename(oldPath, newPath); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Fatal("expected old path to be gone")
+	}
+	if _, err := os.Stat(newPath); err != nil {
+		t.Fatalf("expected new path to exist, got error: %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub")
+	if err := os.MkdirAll(filepath.Join(path, "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Remove(path); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("expected directory to be removed")
+	}
+}
+
+func TestRemoveBatch(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	writeFile(t, a, "a")
+	writeFile(t, b, "b")
+
+	if err := RemoveBatch([]string{a, b}); err != nil {
+		t.Fatalf("RemoveBatch() error = %v", err)
+	}
+	for _, p := range []string{a, b} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed", p)
+		}
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	writeFile(t, src, "contents")
+
+	if err := Copy(src, dst); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	got := readFile(t, dst)
+	if got != "contents" {
+		t.Fatalf("dst contents = %q, want %q", got, "contents")
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Fatalf("expected src to still exist, got error: %v", err)
+	}
+}
+
+func TestCopyDirRecursive(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+	writeFile(t, filepath.Join(src, "nested", "file.txt"), "nested contents")
+
+	if err := Copy(src, dst); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	got := readFile(t, filepath.Join(dst, "nested", "file.txt"))
+	if got != "nested contents" {
+		t.Fatalf("copied contents = %q, want %q", got, "nested contents")
+	}
+}
+
+func TestCopySamePathRefused(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	writeFile(t, src, "contents")
+
+	if err := Copy(src, src); err == nil {
+		t.Fatal("expected error copying a file onto itself, got nil")
+	}
+	if readFile(t, src) != "contents" {
+		t.Fatal("expected src contents to survive a refused self-copy")
+	}
+}
+
+func TestCopyDirIntoOwnSubdirRefused(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	writeFile(t, filepath.Join(src, "file.txt"), "contents")
+	dst := filepath.Join(src, "sub")
+
+	if err := Copy(src, dst); err == nil {
+		t.Fatal("expected error copying a directory into its own subtree, got nil")
+	}
+}
+
+func TestCopyRefusesToOverwriteExistingDest(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	writeFile(t, src, "new")
+	writeFile(t, dst, "old")
+
+	if err := Copy(src, dst); err == nil {
+		t.Fatal("expected error copying onto an existing destination, got nil")
+	}
+	if readFile(t, dst) != "old" {
+		t.Fatal("expected existing destination contents to be left untouched")
+	}
+}
+
+func TestCopyBatch(t *testing.T) {
+	dir := t.TempDir()
+	destDir := filepath.Join(dir, "dest")
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	writeFile(t, a, "a")
+	writeFile(t, b, "b")
+
+	if err := CopyBatch([]string{a, b}, destDir); err != nil {
+		t.Fatalf("CopyBatch() error = %v", err)
+	}
+	if readFile(t, filepath.Join(destDir, "a.txt")) != "a" {
+		t.Fatal("a.txt not copied correctly")
+	}
+	if readFile(t, filepath.Join(destDir, "b.txt")) != "b" {
+		t.Fatal("b.txt not copied correctly")
+	}
+}
+
+func TestMoveBatch(t *testing.T) {
+	dir := t.TempDir()
+	destDir := filepath.Join(dir, "dest")
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a := filepath.Join(dir, "a.txt")
+	writeFile(t, a, "a")
+
+	if err := MoveBatch([]string{a}, destDir); err != nil {
+		t.Fatalf("MoveBatch() error = %v", err)
+	}
+	if _, err := os.Stat(a); !os.IsNotExist(err) {
+		t.Fatal("expected source to be moved away")
+	}
+	if readFile(t, filepath.Join(destDir, "a.txt")) != "a" {
+		t.Fatal("a.txt not moved correctly")
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
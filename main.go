@@ -1,17 +1,24 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/u-hossy/path-cp/internal/fileops"
 )
 
 const (
@@ -24,17 +31,43 @@ const (
 	titleColor    = "62"
 	selectedColor = "170"
 	helpColor     = "241"
+	markColor     = "212"
+	promptColor   = "214"
+	matchColor    = "205"
+	previewColor  = "243"
+
+	iconSelectDir = "✅ "
 )
 
 var (
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(2)
 	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(0).Foreground(lipgloss.Color(selectedColor))
+	markedItemStyle   = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color(markColor))
+	matchStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(matchColor)).Bold(true)
 	titleStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(titleColor)).Bold(true)
 	helpStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(helpColor)).Padding(1, 0, 0, 2)
+	promptStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color(promptColor)).Padding(1, 0, 0, 2)
+	previewPaneStyle  = lipgloss.NewStyle().Padding(0, 1).BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color(previewColor)).BorderLeft(true).BorderTop(false).BorderRight(false).BorderBottom(false)
+)
+
+// treeState tracks which file-operation prompt, if any, currently owns
+// the keyboard instead of the list navigation.
+type treeState int
+
+const (
+	IdleState treeState = iota
+	CreateFileState
+	CreateDirectoryState
+	RenameState
+	MoveState
+	CopyState
+	DeleteConfirmState
+	JumpState
 )
 
 type dirEntry struct {
-	entry fs.DirEntry
+	entry  fs.DirEntry
+	marked bool
 }
 
 func (d dirEntry) FilterValue() string {
@@ -45,8 +78,11 @@ func (d dirEntry) icon() string {
 	if !d.entry.IsDir() {
 		return iconFile
 	}
-	if d.entry.Name() == ".." {
+	switch d.entry.Name() {
+	case "..":
 		return iconParentDir
+	case ".":
+		return iconSelectDir
 	}
 	return iconDirectory
 }
@@ -58,6 +94,16 @@ func (p *parentDirEntry) IsDir() bool                { return true }
 func (p *parentDirEntry) Type() fs.FileMode          { return fs.ModeDir }
 func (p *parentDirEntry) Info() (fs.FileInfo, error) { return nil, nil }
 
+// currentDirEntry is a synthetic list entry shown in --dir-only mode that
+// lets the user pick the directory currently being browsed, rather than
+// descending into one of its children.
+type currentDirEntry struct{}
+
+func (c *currentDirEntry) Name() string               { return "." }
+func (c *currentDirEntry) IsDir() bool                { return true }
+func (c *currentDirEntry) Type() fs.FileMode          { return fs.ModeDir }
+func (c *currentDirEntry) Info() (fs.FileInfo, error) { return nil, nil }
+
 type itemDelegate struct{}
 
 func (d itemDelegate) Height() int  { return 1 }
@@ -72,13 +118,50 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		return
 	}
 
-	str := fmt.Sprintf("%s %s", item.icon(), item.entry.Name())
+	name := item.entry.Name()
+	if name == "." {
+		name = "Select this directory"
+	}
 
-	if index == m.Index() {
+	switch {
+	case index == m.Index():
+		str := fmt.Sprintf("%s %s", item.icon(), name)
+		if item.marked {
+			str = "✓ " + str
+		}
 		fmt.Fprint(w, selectedItemStyle.Render(iconCurrent+str))
-		return
+	case item.marked:
+		str := fmt.Sprintf("%s %s", item.icon(), name)
+		fmt.Fprint(w, markedItemStyle.Render("✓ "+str))
+	default:
+		displayName := highlightMatches(name, m.MatchesForItem(index))
+		fmt.Fprint(w, itemStyle.Render(fmt.Sprintf("%s %s", item.icon(), displayName)))
+	}
+}
+
+// highlightMatches renders s with matched positions styled distinctly,
+// used to show which characters satisfied the active list filter. matched
+// holds byte offsets into s (as produced by both sahilm/fuzzy and
+// substringFilter), so s is walked by byte index rather than by rune.
+func highlightMatches(s string, matched []int) string {
+	if len(matched) == 0 {
+		return s
+	}
+
+	matchSet := make(map[int]bool, len(matched))
+	for _, idx := range matched {
+		matchSet[idx] = true
 	}
-	fmt.Fprint(w, itemStyle.Render(str))
+
+	var b strings.Builder
+	for i, r := range s {
+		if matchSet[i] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
 
 type model struct {
@@ -88,6 +171,29 @@ type model struct {
 	err         error
 	copyPath    bool
 	copyFormat  string
+	config      Config
+
+	state     treeState
+	input     textinput.Model
+	selected  map[string]struct{}
+	pendingOp []string
+
+	fuzzyMode bool
+
+	navStack      []navFrame
+	restoreCursor int
+
+	watcher           *fsnotify.Watcher
+	notify            func(tea.Msg)
+	pendingCursorName string
+
+	formats *formatRegistry
+
+	termWidth      int
+	previewPath    string
+	previewContent string
+	previewGen     int
+	previewCache   *previewCache
 }
 
 type errMsg struct {
@@ -99,8 +205,16 @@ type dirChangeMsg struct {
 	entries []fs.DirEntry
 }
 
-func newModel() (model, error) {
-	currentPath, err := os.Getwd()
+func newModel(cfg Config, formats *formatRegistry) (model, error) {
+	currentPath := cfg.StartDir
+	if currentPath == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return model{}, err
+		}
+		currentPath = wd
+	}
+	currentPath, err := filepath.Abs(currentPath)
 	if err != nil {
 		return model{}, err
 	}
@@ -110,77 +224,137 @@ func newModel() (model, error) {
 		return model{}, err
 	}
 
-	items := createListItems(entries)
-	l := createList(items, currentPath)
+	selected := make(map[string]struct{})
+	items := createListItems(entries, currentPath, selected, cfg)
+	l := createList(items, currentPath, cfg)
+
+	ti := textinput.New()
+	ti.CharLimit = 256
 
 	return model{
-		initialPath: currentPath,
-		currentPath: currentPath,
-		list:        l,
+		initialPath:   currentPath,
+		currentPath:   currentPath,
+		list:          l,
+		input:         ti,
+		selected:      selected,
+		config:        cfg,
+		fuzzyMode:     true,
+		restoreCursor: -1,
+		formats:       formats,
+		previewCache:  newPreviewCache(previewCacheSize),
 	}, nil
 }
 
-func createList(items []list.Item, path string) list.Model {
+func createList(items []list.Item, path string, cfg Config) list.Model {
 	l := list.New(items, itemDelegate{}, 0, 0)
 	l.Title = titlePrefix + path
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(true)
+	l.Filter = fuzzyFilter
 	l.Styles.Title = titleStyle
+	if cfg.Height > 0 {
+		l.SetHeight(cfg.Height)
+	}
 	return l
 }
 
-func createListItems(entries []fs.DirEntry) []list.Item {
-	dirs, files := partitionEntries(entries)
+func createListItems(entries []fs.DirEntry, dirPath string, selected map[string]struct{}, cfg Config) []list.Item {
+	dirs, files := partitionEntries(entries, cfg)
 
 	sortByName(dirs)
 	sortByName(files)
 
-	sortedEntries := make([]fs.DirEntry, 0, len(entries)+1)
+	sortedEntries := make([]fs.DirEntry, 0, len(entries)+2)
+	if cfg.DirOnly {
+		sortedEntries = append(sortedEntries, &currentDirEntry{})
+	}
 	sortedEntries = append(sortedEntries, &parentDirEntry{})
 	sortedEntries = append(sortedEntries, dirs...)
 	sortedEntries = append(sortedEntries, files...)
 
-	return convertToListItems(sortedEntries)
+	return convertToListItems(sortedEntries, dirPath, selected)
 }
 
-func partitionEntries(entries []fs.DirEntry) (dirs, files []fs.DirEntry) {
+func partitionEntries(entries []fs.DirEntry, cfg Config) (dirs, files []fs.DirEntry) {
 	for _, entry := range entries {
+		if !cfg.ShowHidden && isHidden(entry) {
+			continue
+		}
 		if entry.IsDir() {
-			dirs = append(dirs, entry)
-		} else {
+			if !cfg.FileOnly {
+				dirs = append(dirs, entry)
+			}
+		} else if !cfg.DirOnly {
 			files = append(files, entry)
 		}
 	}
 	return dirs, files
 }
 
+func isHidden(entry fs.DirEntry) bool {
+	name := entry.Name()
+	return len(name) > 0 && name[0] == '.'
+}
+
 func sortByName(entries []fs.DirEntry) {
 	sort.Slice(entries, func(i, j int) bool {
 		return entries[i].Name() < entries[j].Name()
 	})
 }
 
-func convertToListItems(entries []fs.DirEntry) []list.Item {
+func convertToListItems(entries []fs.DirEntry, dirPath string, selected map[string]struct{}) []list.Item {
 	items := make([]list.Item, len(entries))
 	for i, entry := range entries {
-		items[i] = dirEntry{entry: entry}
+		_, marked := selected[filepath.Join(dirPath, entry.Name())]
+		items[i] = dirEntry{entry: entry, marked: marked}
 	}
 	return items
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.SetWindowTitle("cd-plus - Interactive Directory Navigator")
+	return tea.Batch(
+		tea.SetWindowTitle("cd-plus - Interactive Directory Navigator"),
+		func() tea.Msg { return watchStartMsg{} },
+		func() tea.Msg { return previewRequestMsg{path: m.getSelectedPath(), gen: m.previewGen} },
+	)
 }
 
+// Update delegates to updateTree and, if that changed which entry is
+// highlighted, piggybacks a preview refresh for the new selection onto the
+// returned command.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prevPath := m.getSelectedPath()
+
+	newModel, cmd := m.updateTree(msg)
+	nm := newModel.(model)
+
+	if nm.getSelectedPath() != prevPath {
+		var previewCmd tea.Cmd
+		nm, previewCmd = nm.schedulePreview()
+		cmd = tea.Batch(cmd, previewCmd)
+	}
+	return nm, cmd
+}
+
+func (m model) updateTree(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowResize(msg), nil
 	case dirChangeMsg:
-		return m.handleDirChange(msg), nil
+		m = m.handleDirChange(msg)
+		return m.restartWatcher(), nil
+	case watchStartMsg:
+		return m.restartWatcher(), nil
+	case fsEventMsg:
+		m.pendingCursorName = m.currentEntryName()
+		return m, m.refreshCurrentDir()
 	case errMsg:
 		m.err = msg.err
 		return m, nil
+	case previewRequestMsg:
+		return m.handlePreviewRequest(msg)
+	case previewMsg:
+		return m.handlePreviewResult(msg)
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 	}
@@ -191,22 +365,73 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) handleWindowResize(msg tea.WindowSizeMsg) model {
-	m.list.SetWidth(msg.Width)
-	m.list.SetHeight(msg.Height - 2)
+	m.termWidth = msg.Width
+	m.list.SetWidth(msg.Width - previewPaneWidth(msg.Width) - previewBorderWidth)
+	if m.config.Height == 0 {
+		m.list.SetHeight(msg.Height - 2)
+	}
 	return m
 }
 
 func (m model) handleDirChange(msg dirChangeMsg) model {
 	m.currentPath = msg.path
-	items := createListItems(msg.entries)
+	items := createListItems(msg.entries, msg.path, m.selected, m.config)
 	m.list.SetItems(items)
 	m.list.Title = titlePrefix + msg.path
-	m.list.ResetSelected()
+	m.previewCache.invalidateDir(msg.path)
+
+	switch {
+	case m.pendingCursorName != "":
+		if index := indexOfEntryName(items, m.pendingCursorName); index >= 0 {
+			m.list.Select(index)
+		} else {
+			m.list.ResetSelected()
+		}
+	case m.restoreCursor >= 0:
+		m.list.Select(m.restoreCursor)
+	default:
+		m.list.ResetSelected()
+	}
+	m.pendingCursorName = ""
+	m.restoreCursor = -1
+
 	m.list.ResetFilter()
 	return m
 }
 
+// indexOfEntryName returns the index of the item whose entry name matches
+// name, or -1 if none matches.
+func indexOfEntryName(items []list.Item, name string) int {
+	for i, item := range items {
+		if item.(dirEntry).entry.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// refreshCurrentDir re-reads m.currentPath and returns a dirChangeMsg,
+// the same refresh path used after navigating into a directory. File
+// operations use it to pick up the change they just made on disk.
+func (m model) refreshCurrentDir() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := os.ReadDir(m.currentPath)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dirChangeMsg{path: m.currentPath, entries: entries}
+	}
+}
+
 func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.state != IdleState {
+		return m.handlePromptKeyPress(msg)
+	}
+
+	if msg.String() == "ctrl+t" {
+		return m.toggleFilterMode(), nil
+	}
+
 	if m.list.FilterState() == list.Filtering {
 		var cmd tea.Cmd
 		m.list, cmd = m.list.Update(msg)
@@ -217,30 +442,35 @@ func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+c", "q":
 		m.copyPath = false
 		return m, tea.Quit
-	case "f":
-		m.copyPath = true
-		m.copyFormat = "f"
-		return m, tea.Quit
-	case "r":
-		m.copyPath = true
-		m.copyFormat = "r"
-		return m, tea.Quit
-	case "a":
-		m.copyPath = true
-		m.copyFormat = "a"
-		return m, tea.Quit
-	case "d":
-		m.copyPath = true
-		m.copyFormat = "d"
-		return m, tea.Quit
-	case "p":
-		m.copyPath = true
-		m.copyFormat = "p"
-		return m, tea.Quit
-	case "enter", " ", "l", "right":
+	case "enter", "l", "right":
 		return m.handleEnterDirectory()
 	case "backspace", "h", "left":
-		return m, m.navigateToParent()
+		return m.goBack()
+	case "g":
+		return m.enterJumpState(), nil
+	case " ":
+		m.toggleMarked()
+		return m, nil
+	case "n":
+		return m.enterPrompt(CreateFileState, ""), nil
+	case "N":
+		return m.enterPrompt(CreateDirectoryState, ""), nil
+	case "R":
+		return m.enterPrompt(RenameState, m.currentEntryName()), nil
+	case "m":
+		return m.enterBatchPrompt(MoveState), nil
+	case "c":
+		return m.enterBatchPrompt(CopyState), nil
+	case "x":
+		return m.enterBatchPrompt(DeleteConfirmState), nil
+	default:
+		if len(msg.Runes) == 1 {
+			if _, ok := m.formats.lookup(msg.Runes[0]); ok {
+				m.copyPath = true
+				m.copyFormat = string(msg.Runes[0])
+				return m, tea.Quit
+			}
+		}
 	}
 
 	var cmd tea.Cmd
@@ -259,7 +489,17 @@ func (m model) handleEnterDirectory() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	return m, m.navigateTo(entry.Name())
+	if m.config.DirOnly && entry.Name() == "." {
+		m.copyPath = true
+		m.copyFormat = "d"
+		return m, tea.Quit
+	}
+
+	if entry.Name() == ".." {
+		return m.goBack()
+	}
+
+	return m.goForward(entry.Name())
 }
 
 func (m model) navigateTo(dirname string) tea.Cmd {
@@ -277,10 +517,6 @@ func (m model) navigateTo(dirname string) tea.Cmd {
 	}
 }
 
-func (m model) navigateToParent() tea.Cmd {
-	return m.navigateTo("..")
-}
-
 func (m model) resolveNewPath(dirname string) string {
 	if dirname == ".." {
 		return filepath.Dir(m.currentPath)
@@ -288,16 +524,302 @@ func (m model) resolveNewPath(dirname string) string {
 	return filepath.Join(m.currentPath, dirname)
 }
 
+// toggleFilterMode flips between fuzzy and substring matching for the list
+// filter, re-ranking whatever filter term is currently in effect.
+func (m model) toggleFilterMode() model {
+	m.fuzzyMode = !m.fuzzyMode
+	if m.fuzzyMode {
+		m.list.Filter = fuzzyFilter
+	} else {
+		m.list.Filter = substringFilter
+	}
+	return m
+}
+
+// currentEntryName returns the name of the highlighted entry, or "" if
+// nothing is selected or the entry is one of the synthetic placeholders
+// (the parent-dir ".." entry, or the dir-only "select this dir" "." entry).
+func (m model) currentEntryName() string {
+	selectedItem := m.list.SelectedItem()
+	if selectedItem == nil {
+		return ""
+	}
+	name := selectedItem.(dirEntry).entry.Name()
+	if name == ".." || name == "." {
+		return ""
+	}
+	return name
+}
+
+// toggleMarked adds or removes the highlighted entry from the multi-select
+// set and refreshes the list so the mark is visible immediately.
+func (m *model) toggleMarked() {
+	name := m.currentEntryName()
+	if name == "" {
+		return
+	}
+
+	path := filepath.Join(m.currentPath, name)
+	if _, ok := m.selected[path]; ok {
+		delete(m.selected, path)
+	} else {
+		m.selected[path] = struct{}{}
+	}
+
+	index := m.list.Index()
+	m.list.SetItems(createListItems(dirEntriesFromItems(m.list.Items()), m.currentPath, m.selected, m.config))
+	m.list.Select(index)
+}
+
+// clearMarked removes paths from the multi-select set once an operation
+// that consumed them (move, copy, delete) has been dispatched.
+func (m model) clearMarked(paths []string) {
+	for _, path := range paths {
+		delete(m.selected, path)
+	}
+}
+
+// dirEntriesFromItems recovers the underlying fs.DirEntry slice from the
+// list's current items, skipping the synthetic parent-dir and
+// select-this-dir entries so createListItems can re-add them.
+func dirEntriesFromItems(items []list.Item) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(items))
+	for _, item := range items {
+		entry := item.(dirEntry).entry
+		if entry.Name() == ".." || entry.Name() == "." {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// markedOrCurrent returns the absolute paths to operate on: the
+// multi-select set if non-empty, otherwise just the highlighted entry.
+func (m model) markedOrCurrent() []string {
+	if len(m.selected) > 0 {
+		paths := make([]string, 0, len(m.selected))
+		for path := range m.selected {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	name := m.currentEntryName()
+	if name == "" {
+		return nil
+	}
+	return []string{filepath.Join(m.currentPath, name)}
+}
+
+// enterPrompt switches into state with the text input focused and
+// pre-filled with prefill (used for rename).
+func (m model) enterPrompt(state treeState, prefill string) model {
+	m.state = state
+	m.input.SetValue(prefill)
+	m.input.CursorEnd()
+	m.input.Focus()
+	return m
+}
+
+// enterBatchPrompt switches into state for an operation that acts on
+// markedOrCurrent, bailing out to IdleState if there is nothing to act on.
+func (m model) enterBatchPrompt(state treeState) model {
+	paths := m.markedOrCurrent()
+	if len(paths) == 0 {
+		return m
+	}
+	m.pendingOp = paths
+
+	if state == DeleteConfirmState {
+		m.state = state
+		return m
+	}
+
+	m.state = state
+	m.input.SetValue(m.currentPath)
+	m.input.CursorEnd()
+	m.input.Focus()
+	return m
+}
+
+// cancelPrompt returns to IdleState, clearing the input and pending batch.
+func (m model) cancelPrompt() model {
+	m.state = IdleState
+	m.pendingOp = nil
+	m.input.Blur()
+	m.input.Reset()
+	return m
+}
+
+// handlePromptKeyPress routes keys while a file-operation prompt is
+// active: esc cancels, enter confirms, everything else edits the input
+// (except for DeleteConfirmState, which only understands y/n).
+func (m model) handlePromptKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.state == JumpState {
+		return m.handleJumpKeyPress(msg)
+	}
+
+	if m.state == DeleteConfirmState {
+		switch msg.String() {
+		case "y", "enter":
+			paths := m.pendingOp
+			m = m.cancelPrompt()
+			m.clearMarked(paths)
+			return m, m.runFileOp(func() error {
+				return fileops.RemoveBatch(paths)
+			})
+		case "n", "esc", "ctrl+c":
+			return m.cancelPrompt(), nil
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		return m.cancelPrompt(), nil
+	case "enter":
+		return m.confirmPrompt()
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// confirmPrompt runs the filesystem operation for the active state using
+// the current input value, then returns to IdleState.
+func (m model) confirmPrompt() (tea.Model, tea.Cmd) {
+	value := m.input.Value()
+	state := m.state
+	pending := m.pendingOp
+	currentPath := m.currentPath
+	m = m.cancelPrompt()
+
+	switch state {
+	case CreateFileState:
+		if value == "" {
+			return m, nil
+		}
+		path := filepath.Join(currentPath, value)
+		return m, m.runFileOp(func() error { return fileops.CreateFile(path) })
+	case CreateDirectoryState:
+		if value == "" {
+			return m, nil
+		}
+		path := filepath.Join(currentPath, value)
+		return m, m.runFileOp(func() error { return fileops.CreateDir(path) })
+	case RenameState:
+		name := m.currentEntryName()
+		if value == "" || name == "" {
+			return m, nil
+		}
+		oldPath := filepath.Join(currentPath, name)
+		newPath := filepath.Join(currentPath, value)
+		return m, m.runFileOp(func() error { return fileops.Rename(oldPath, newPath) })
+	case MoveState:
+		if value == "" {
+			return m, nil
+		}
+		m.clearMarked(pending)
+		return m, m.runFileOp(func() error { return fileops.MoveBatch(pending, value) })
+	case CopyState:
+		if value == "" {
+			return m, nil
+		}
+		m.clearMarked(pending)
+		return m, m.runFileOp(func() error { return fileops.CopyBatch(pending, value) })
+	}
+
+	return m, nil
+}
+
+// runFileOp runs op and, on success, refreshes the current directory;
+// on failure it surfaces the error via errMsg, the same path navigation
+// errors already use.
+func (m model) runFileOp(op func() error) tea.Cmd {
+	refresh := m.refreshCurrentDir()
+	return func() tea.Msg {
+		if err := op(); err != nil {
+			return errMsg{err}
+		}
+		return refresh()
+	}
+}
+
 func (m model) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress q to quit.\n", m.err)
 	}
 
+	breadcrumb := breadcrumbPaddingStyle.Render(m.renderBreadcrumb())
+
+	if m.state != IdleState {
+		return breadcrumb + "\n" + m.list.View() + "\n" + promptStyle.Render(m.promptText())
+	}
+
+	filterMode := "fuzzy"
+	if !m.fuzzyMode {
+		filterMode = "substring"
+	}
+
 	helpText := helpStyle.Render(
-		"Copy: f (file) • r (relative) • a (absolute) • d (directory path) • p (`$HOME` format)",
+		m.formats.helpText() + "\n" +
+			"Ops: n (new file) • N (new dir) • R (rename) • space (mark) • m (move) • c (copy) • x (delete)\n" +
+			"Nav: g (jump to recent dir)\n" +
+			fmt.Sprintf("Filter: %s match (ctrl+t to toggle)", filterMode),
 	)
 
-	return m.list.View() + "\n" + helpText
+	body := lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), m.renderPreviewPane())
+
+	return breadcrumb + "\n" + body + "\n" + helpText
+}
+
+// renderPreviewPane renders the right-hand preview panel for the
+// highlighted entry, sized to match the list and vertically offset to line
+// up with the list's item rows below its title bar.
+func (m model) renderPreviewPane() string {
+	content := m.previewContent
+	if m.previewPath != m.getSelectedPath() {
+		content = "Loading…"
+	}
+	content = strings.Repeat("\n", m.previewHeaderHeight()) + content
+
+	return previewPaneStyle.
+		Width(previewPaneWidth(m.termWidth)).
+		Height(m.list.Height()).
+		Render(content)
+}
+
+// previewHeaderHeight reports how many blank lines the preview pane needs
+// at its top so its content starts level with the list's first item row,
+// matching the space the list's title bar occupies.
+func (m model) previewHeaderHeight() int {
+	return lipgloss.Height(m.list.Styles.TitleBar.Render(m.list.Title))
+}
+
+// promptText renders the line shown below the list while a file-operation
+// prompt is active.
+func (m model) promptText() string {
+	switch m.state {
+	case CreateFileState:
+		return "New file name: " + m.input.View()
+	case CreateDirectoryState:
+		return "New directory name: " + m.input.View()
+	case RenameState:
+		return "Rename to: " + m.input.View()
+	case MoveState:
+		return fmt.Sprintf("Move %d item(s) to: %s", len(m.pendingOp), m.input.View())
+	case CopyState:
+		return fmt.Sprintf("Copy %d item(s) to: %s", len(m.pendingOp), m.input.View())
+	case DeleteConfirmState:
+		return fmt.Sprintf("Delete %d item(s)? (y/n)", len(m.pendingOp))
+	case JumpState:
+		return m.jumpPromptText()
+	default:
+		return ""
+	}
 }
 
 func (m model) getSelectedPath() string {
@@ -317,65 +839,49 @@ func (m model) getSelectedPath() string {
 func (m model) getFormattedPath() (string, error) {
 	selectedPath := m.getSelectedPath()
 
-	switch m.copyFormat {
-	case "f":
-		return filepath.Base(selectedPath), nil
-
-	case "r":
-		relPath, err := filepath.Rel(m.initialPath, selectedPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to get relative path: %w", err)
-		}
-		return relPath, nil
-
-	case "a":
-		absPath, err := filepath.Abs(selectedPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to get absolute path: %w", err)
-		}
-		return absPath, nil
-
-	case "d":
-		absPath, err := filepath.Abs(selectedPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to get absolute path: %w", err)
-		}
-		if info, err := os.Stat(absPath); err == nil && !info.IsDir() {
-			return filepath.Dir(absPath), nil
-		}
-		return absPath, nil
+	keyRunes := []rune(m.copyFormat)
+	if len(keyRunes) != 1 {
+		return "", fmt.Errorf("unknown copy format %q", m.copyFormat)
+	}
+	formatter, ok := m.formats.lookup(keyRunes[0])
+	if !ok {
+		return "", fmt.Errorf("unknown copy format %q", m.copyFormat)
+	}
 
-	case "p":
-		absPath, err := filepath.Abs(selectedPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to get absolute path: %w", err)
-		}
-		dirPath := absPath
-		if info, err := os.Stat(absPath); err == nil && !info.IsDir() {
-			dirPath = filepath.Dir(absPath)
-		}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = ""
+	}
+	return formatter.Format(selectedPath, m.initialPath, homeDir)
+}
 
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			relPath, err := filepath.Rel(homeDir, dirPath)
-			if err == nil && !filepath.IsAbs(relPath) && len(relPath) > 0 && relPath[0] != '.' {
-				return filepath.Join("$HOME", relPath), nil
-			}
+func run() error {
+	cfg, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
 		}
-		return dirPath, nil
+		return err
+	}
 
-	default:
-		return selectedPath, nil
+	formats := newFormatRegistry()
+	if err := loadUserFormats(formats, userFormatsPath()); err != nil {
+		return fmt.Errorf("failed to load user formats: %w", err)
 	}
-}
 
-func run() error {
-	m, err := newModel()
+	m, err := newModel(cfg, formats)
 	if err != nil {
 		return fmt.Errorf("failed to create model: %w", err)
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	var p *tea.Program
+	m.notify = func(msg tea.Msg) {
+		if p != nil {
+			p.Send(msg)
+		}
+	}
+
+	p = tea.NewProgram(m, tea.WithAltScreen())
 	finalModel, err := p.Run()
 	if err != nil {
 		return fmt.Errorf("error running program: %w", err)
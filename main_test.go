@@ -0,0 +1,11 @@
+package main
+
+import "testing"
+
+func TestHighlightMatchesMultibyte(t *testing.T) {
+	got := highlightMatches("日本語.txt", []int{0, 3})
+	want := matchStyle.Render("日") + "本" + matchStyle.Render("語") + ".txt"
+	if got != want {
+		t.Fatalf("highlightMatches() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	breadcrumbColor        = "105"
+	breadcrumbCurrentColor = "228"
+
+	maxRecentDirs = 9
+)
+
+var (
+	breadcrumbStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color(breadcrumbColor))
+	breadcrumbCurrentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(breadcrumbCurrentColor)).Bold(true)
+	breadcrumbSepStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(helpColor))
+	breadcrumbPaddingStyle = lipgloss.NewStyle().Padding(0, 0, 0, 2)
+)
+
+// navFrame records where the cursor was sitting in a directory at the
+// moment the user descended out of it, so returning to it can restore
+// that position instead of resetting to the top.
+type navFrame struct {
+	path   string
+	cursor int
+}
+
+// goForward pushes the current directory and cursor onto the nav stack
+// and descends into name.
+func (m model) goForward(name string) (tea.Model, tea.Cmd) {
+	m.navStack = append(m.navStack, navFrame{path: m.currentPath, cursor: m.list.Index()})
+	m.restoreCursor = -1
+	return m, m.navigateTo(name)
+}
+
+// goBack pops the nav stack if its top frame matches the parent
+// directory, restoring the cursor position it recorded, then navigates
+// up to the parent.
+func (m model) goBack() (tea.Model, tea.Cmd) {
+	parent := filepath.Dir(m.currentPath)
+	m.restoreCursor = -1
+	if n := len(m.navStack); n > 0 && m.navStack[n-1].path == parent {
+		m.restoreCursor = m.navStack[n-1].cursor
+		m.navStack = m.navStack[:n-1]
+	}
+	return m, m.navigateTo("..")
+}
+
+// renderBreadcrumb renders m.currentPath as separately styled path
+// segments, the current (last) segment styled distinctly from its
+// ancestors.
+func (m model) renderBreadcrumb() string {
+	trimmed := strings.Trim(m.currentPath, string(filepath.Separator))
+	parts := strings.Split(trimmed, string(filepath.Separator))
+
+	segments := make([]string, len(parts))
+	for i, part := range parts {
+		if part == "" {
+			part = string(filepath.Separator)
+		}
+		if i == len(parts)-1 {
+			segments[i] = breadcrumbCurrentStyle.Render(part)
+		} else {
+			segments[i] = breadcrumbStyle.Render(part)
+		}
+	}
+	return strings.Join(segments, breadcrumbSepStyle.Render(" › "))
+}
+
+// recentDirs returns up to maxRecentDirs previously visited directories,
+// most recent first and deduplicated, for the "g" jump-back prompt.
+func (m model) recentDirs() []string {
+	seen := map[string]bool{m.currentPath: true}
+	var dirs []string
+	for i := len(m.navStack) - 1; i >= 0 && len(dirs) < maxRecentDirs; i-- {
+		path := m.navStack[i].path
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		dirs = append(dirs, path)
+	}
+	return dirs
+}
+
+// enterJumpState opens the recent-directories prompt, a no-op if there is
+// nothing to jump to.
+func (m model) enterJumpState() model {
+	if len(m.recentDirs()) == 0 {
+		return m
+	}
+	m.state = JumpState
+	return m
+}
+
+// handleJumpKeyPress handles keys while the recent-directories prompt is
+// active: a digit 1-9 jumps to the matching entry, esc/ctrl+c cancels.
+func (m model) handleJumpKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		return m.cancelPrompt(), nil
+	}
+
+	n, err := strconv.Atoi(msg.String())
+	if err != nil {
+		return m, nil
+	}
+
+	dirs := m.recentDirs()
+	index := n - 1
+	if index < 0 || index >= len(dirs) {
+		return m, nil
+	}
+
+	path := dirs[index]
+	m = m.cancelPrompt()
+	m.restoreCursor = -1
+	return m, m.navigateToAbsolute(path)
+}
+
+// jumpPromptText renders the numbered list of recent directories shown
+// while JumpState is active.
+func (m model) jumpPromptText() string {
+	dirs := m.recentDirs()
+	lines := make([]string, len(dirs))
+	for i, dir := range dirs {
+		lines[i] = fmt.Sprintf("%d: %s", i+1, dir)
+	}
+	return "Jump to recent directory (esc to cancel):\n" + strings.Join(lines, "\n")
+}
+
+// navigateToAbsolute reads path and delivers it as a dirChangeMsg,
+// bypassing the relative resolveNewPath logic navigateTo uses.
+func (m model) navigateToAbsolute(path string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dirChangeMsg{path: path, entries: entries}
+	}
+}
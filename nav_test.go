@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestRecentDirsDedupesAndOrdersMostRecentFirst(t *testing.T) {
+	m := model{
+		currentPath: "/a/b/c",
+		navStack: []navFrame{
+			{path: "/a", cursor: 0},
+			{path: "/a/b", cursor: 1},
+			{path: "/a", cursor: 2},
+		},
+	}
+
+	dirs := m.recentDirs()
+	want := []string{"/a", "/a/b"}
+	if len(dirs) != len(want) {
+		t.Fatalf("recentDirs() = %v, want %v", dirs, want)
+	}
+	for i, d := range want {
+		if dirs[i] != d {
+			t.Fatalf("recentDirs()[%d] = %q, want %q", i, dirs[i], d)
+		}
+	}
+}
+
+func TestRecentDirsExcludesCurrentPath(t *testing.T) {
+	m := model{
+		currentPath: "/a/b",
+		navStack:    []navFrame{{path: "/a/b", cursor: 0}},
+	}
+
+	if dirs := m.recentDirs(); len(dirs) != 0 {
+		t.Fatalf("recentDirs() = %v, want empty", dirs)
+	}
+}
+
+func TestRenderBreadcrumb(t *testing.T) {
+	m := model{currentPath: "/a/b/c"}
+	got := m.renderBreadcrumb()
+	if got == "" {
+		t.Fatal("renderBreadcrumb() returned empty string")
+	}
+}
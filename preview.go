@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	previewDebounce  = 150 * time.Millisecond
+	previewMaxLines  = 40
+	previewSniffSize = 512
+	previewCacheSize = 16
+	previewMinWidth  = 20
+)
+
+// previewRequestMsg is sent previewDebounce after the highlighted entry
+// changes. If gen no longer matches the model's current generation by the
+// time it arrives, the cursor has since moved on and the request is stale.
+type previewRequestMsg struct {
+	path string
+	gen  int
+}
+
+// previewMsg carries the rendered preview body back from the background
+// load kicked off by a non-stale previewRequestMsg.
+type previewMsg struct {
+	path    string
+	gen     int
+	content string
+}
+
+// schedulePreview bumps the preview generation for the newly highlighted
+// path and, unless it's already cached, returns a debounced command that
+// will request it be loaded.
+func (m model) schedulePreview() (model, tea.Cmd) {
+	path := m.getSelectedPath()
+	m.previewGen++
+	gen := m.previewGen
+
+	if content, ok := m.previewCache.get(path); ok {
+		m.previewPath = path
+		m.previewContent = content
+		return m, nil
+	}
+
+	return m, tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewRequestMsg{path: path, gen: gen}
+	})
+}
+
+// handlePreviewRequest loads the preview for msg.path, unless a later
+// cursor move has already made the request stale.
+func (m model) handlePreviewRequest(msg previewRequestMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.previewGen {
+		return m, nil
+	}
+	return m, m.loadPreviewCmd(msg.path, msg.gen)
+}
+
+func (m model) loadPreviewCmd(path string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		return previewMsg{path: path, gen: gen, content: renderPreview(path)}
+	}
+}
+
+// handlePreviewResult stores a freshly loaded preview, unless it has since
+// gone stale.
+func (m model) handlePreviewResult(msg previewMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.previewGen {
+		return m, nil
+	}
+	m.previewPath = msg.path
+	m.previewContent = msg.content
+	m.previewCache.put(msg.path, msg.content)
+	return m, nil
+}
+
+// previewBorderWidth is the extra column previewPaneStyle's left border
+// adds on top of the content width passed to Width(), which the caller
+// must also reserve when sizing the list alongside it.
+const previewBorderWidth = 1
+
+// previewPaneWidth returns how many content columns the preview pane gets
+// for a terminal of the given total width. It targets a third of the
+// terminal, floored at previewMinWidth and capped at half the terminal —
+// unless the terminal is so narrow that half of it can't meet the floor,
+// in which case the floor wins so the pane stays usable.
+func previewPaneWidth(total int) int {
+	w := total / 3
+	if w < previewMinWidth {
+		w = previewMinWidth
+	}
+	if half := total / 2; w > half && half >= previewMinWidth {
+		w = half
+	}
+	return w
+}
+
+// renderPreview renders the preview body for path: directory listing,
+// or file metadata plus a text excerpt (binaries get metadata only).
+func renderPreview(path string) string {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+
+	if info.IsDir() {
+		return renderDirPreview(path)
+	}
+	return renderFilePreview(path, info)
+}
+
+func renderDirPreview(path string) string {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	if len(entries) == 0 {
+		return "(empty directory)"
+	}
+
+	lines := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		if i == previewMaxLines {
+			lines = append(lines, fmt.Sprintf("… %d more", len(entries)-i))
+			break
+		}
+		icon := iconFile
+		if entry.IsDir() {
+			icon = iconDirectory
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", icon, entry.Name()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderFilePreview(path string, info os.FileInfo) string {
+	header := fmt.Sprintf("%s\n%s  %s", info.Mode(), humanizeSize(info.Size()), info.ModTime().Format("2006-01-02 15:04:05"))
+
+	if info.Size() == 0 {
+		return header + "\n\n(empty file)"
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return header + fmt.Sprintf("\n\n(error reading file: %v)", err)
+	}
+	defer f.Close()
+
+	sniff := make([]byte, previewSniffSize)
+	n, err := f.Read(sniff)
+	if err != nil && err != io.EOF {
+		return header + fmt.Sprintf("\n\n(error reading file: %v)", err)
+	}
+	sniff = sniff[:n]
+
+	if !isTextContentType(http.DetectContentType(sniff)) {
+		return header + "\n\n(binary file, preview unavailable)"
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return header + fmt.Sprintf("\n\n(error reading file: %v)", err)
+	}
+
+	lines, truncated := readLines(f, previewMaxLines)
+	body := strings.Join(lines, "\n")
+	if truncated {
+		body += "\n…"
+	}
+	return header + "\n\n" + body
+}
+
+// isTextContentType reports whether a sniffed MIME type should be shown as
+// text rather than flagged as binary.
+func isTextContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		contentType == "application/json" ||
+		strings.Contains(contentType, "xml")
+}
+
+func readLines(r io.Reader, max int) (lines []string, truncated bool) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(lines) == max {
+			return lines, true
+		}
+		lines = append(lines, scanner.Text())
+	}
+	// scanner.Err() is non-nil for an over-long line (bufio.ErrTooLong) as
+	// well as genuine read errors; either way the file wasn't fully read,
+	// so flag it the same as hitting the line cap.
+	return lines, scanner.Err() != nil
+}
+
+// humanizeSize renders a byte count in the largest unit that keeps it
+// above 1, e.g. "4.2 MiB".
+func humanizeSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// previewCache is a small fixed-size LRU cache of rendered preview bodies,
+// keyed by absolute path, so revisiting an entry doesn't re-read it.
+type previewCache struct {
+	capacity int
+	order    []string
+	entries  map[string]string
+}
+
+func newPreviewCache(capacity int) *previewCache {
+	return &previewCache{capacity: capacity, entries: make(map[string]string)}
+}
+
+func (c *previewCache) get(path string) (string, bool) {
+	content, ok := c.entries[path]
+	if ok {
+		c.touch(path)
+	}
+	return content, ok
+}
+
+func (c *previewCache) put(path, content string) {
+	if _, ok := c.entries[path]; !ok && len(c.order) >= c.capacity {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.entries, oldest)
+	}
+	c.entries[path] = content
+	c.touch(path)
+}
+
+func (c *previewCache) touch(path string) {
+	for i, p := range c.order {
+		if p == path {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, path)
+}
+
+// invalidateDir drops any cached preview for dir itself and for entries
+// directly inside it, since a dirChangeMsg means its listing (and thus
+// anything in it) may no longer match what was cached.
+func (c *previewCache) invalidateDir(dir string) {
+	delete(c.entries, dir)
+	for path := range c.entries {
+		if filepath.Dir(path) == dir {
+			delete(c.entries, path)
+		}
+	}
+	kept := c.order[:0]
+	for _, p := range c.order {
+		if _, ok := c.entries[p]; ok {
+			kept = append(kept, p)
+		}
+	}
+	c.order = kept
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHumanizeSize(t *testing.T) {
+	cases := []struct {
+		size int64
+		want string
+	}{
+		{500, "500 B"},
+		{2048, "2.0 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+	for _, c := range cases {
+		if got := humanizeSize(c.size); got != c.want {
+			t.Errorf("humanizeSize(%d) = %q, want %q", c.size, got, c.want)
+		}
+	}
+}
+
+func TestIsTextContentType(t *testing.T) {
+	cases := []struct {
+		contentType string
+		want        bool
+	}{
+		{"text/plain; charset=utf-8", true},
+		{"application/json", true},
+		{"application/xml", true},
+		{"image/png", false},
+		{"application/octet-stream", false},
+	}
+	for _, c := range cases {
+		if got := isTextContentType(c.contentType); got != c.want {
+			t.Errorf("isTextContentType(%q) = %v, want %v", c.contentType, got, c.want)
+		}
+	}
+}
+
+func TestPreviewCacheEvictsOldest(t *testing.T) {
+	c := newPreviewCache(2)
+	c.put("/a", "A")
+	c.put("/b", "B")
+	c.put("/c", "C")
+
+	if _, ok := c.get("/a"); ok {
+		t.Fatal("expected /a to be evicted")
+	}
+	if content, ok := c.get("/b"); !ok || content != "B" {
+		t.Fatalf("get(/b) = %q, %v, want %q, true", content, ok, "B")
+	}
+	if content, ok := c.get("/c"); !ok || content != "C" {
+		t.Fatalf("get(/c) = %q, %v, want %q, true", content, ok, "C")
+	}
+}
+
+func TestPreviewCacheTouchKeepsRecentlyUsedAlive(t *testing.T) {
+	c := newPreviewCache(2)
+	c.put("/a", "A")
+	c.put("/b", "B")
+	c.get("/a")
+	c.put("/c", "C")
+
+	if _, ok := c.get("/b"); ok {
+		t.Fatal("expected /b to be evicted after /a was touched")
+	}
+	if _, ok := c.get("/a"); !ok {
+		t.Fatal("expected /a to survive eviction")
+	}
+}
+
+func TestRenderDirPreview(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "b.txt"), "hi")
+	writeFile(t, filepath.Join(dir, "a.txt"), "hi")
+
+	got := renderDirPreview(dir)
+	want := iconFile + " a.txt\n" + iconFile + " b.txt"
+	if got != want {
+		t.Fatalf("renderDirPreview() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderDirPreviewEmpty(t *testing.T) {
+	if got := renderDirPreview(t.TempDir()); got != "(empty directory)" {
+		t.Fatalf("renderDirPreview() = %q, want %q", got, "(empty directory)")
+	}
+}
+
+func TestPreviewCacheInvalidateDir(t *testing.T) {
+	c := newPreviewCache(4)
+	c.put("/dir", "dir listing")
+	c.put("/dir/a.txt", "A")
+	c.put("/dir/b.txt", "B")
+	c.put("/other/c.txt", "C")
+
+	c.invalidateDir("/dir")
+
+	if _, ok := c.get("/dir"); ok {
+		t.Fatal("expected /dir to be invalidated")
+	}
+	if _, ok := c.get("/dir/a.txt"); ok {
+		t.Fatal("expected /dir/a.txt to be invalidated")
+	}
+	if _, ok := c.get("/dir/b.txt"); ok {
+		t.Fatal("expected /dir/b.txt to be invalidated")
+	}
+	if content, ok := c.get("/other/c.txt"); !ok || content != "C" {
+		t.Fatalf("get(/other/c.txt) = %q, %v, want %q, true", content, ok, "C")
+	}
+}
+
+func TestPreviewPaneWidth(t *testing.T) {
+	cases := []struct {
+		total int
+		want  int
+	}{
+		{160, 53},
+		{60, 20},
+		{39, 20},
+	}
+	for _, c := range cases {
+		if got := previewPaneWidth(c.total); got != c.want {
+			t.Errorf("previewPaneWidth(%d) = %d, want %d", c.total, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchDebounce = 100 * time.Millisecond
+
+// watchStartMsg triggers the first watcher registration once the program
+// has a way to deliver messages back to itself.
+type watchStartMsg struct{}
+
+// fsEventMsg signals that the watched directory changed on disk and
+// should be re-read.
+type fsEventMsg struct{}
+
+// restartWatcher closes m's current watcher, if any, and registers a new
+// one for m.currentPath. Called whenever the browsed directory changes.
+func (m model) restartWatcher() model {
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+	m.watcher = startWatcher(m.currentPath, m.notify)
+	return m
+}
+
+// startWatcher registers path with fsnotify and starts a goroutine that
+// debounces its events into fsEventMsg, delivered via notify. Returns nil
+// if the directory can't be watched; path-cp still works, just without
+// live updates.
+func startWatcher(path string, notify func(tea.Msg)) *fsnotify.Watcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil
+	}
+
+	go watchLoop(watcher, notify)
+	return watcher
+}
+
+// watchLoop coalesces bursts of fsnotify events (e.g. an editor's
+// write-then-rename save) into a single fsEventMsg per watchDebounce
+// window. It returns once watcher.Close() closes its channels.
+func watchLoop(watcher *fsnotify.Watcher, notify func(tea.Msg)) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, func() {
+				notify(fsEventMsg{})
+			})
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type fakeDirEntry struct {
+	name string
+}
+
+func (f fakeDirEntry) Name() string               { return f.name }
+func (f fakeDirEntry) IsDir() bool                { return false }
+func (f fakeDirEntry) Type() fs.FileMode          { return 0 }
+func (f fakeDirEntry) Info() (fs.FileInfo, error) { return nil, nil }
+
+func TestIndexOfEntryName(t *testing.T) {
+	items := []list.Item{
+		dirEntry{entry: fakeDirEntry{name: "a.txt"}},
+		dirEntry{entry: fakeDirEntry{name: "b.txt"}},
+	}
+
+	if got := indexOfEntryName(items, "b.txt"); got != 1 {
+		t.Fatalf("indexOfEntryName() = %d, want 1", got)
+	}
+	if got := indexOfEntryName(items, "missing"); got != -1 {
+		t.Fatalf("indexOfEntryName() = %d, want -1", got)
+	}
+}